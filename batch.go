@@ -0,0 +1,113 @@
+package gorm_generics
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm/clause"
+)
+
+// InsertBatch creates entities in chunks of chunkSize, using
+// db.CreateInBatches per chunk so large slices don't blow past the
+// driver's max placeholder/packet limits. A chunkSize <= 0 inserts
+// everything in a single batch. If a chunk fails, the error identifies
+// which chunk so the caller knows how much progress was made. Like Insert,
+// it runs the repository's AuditHook (if any) over every entity first.
+func (r *GormRepository[M, E]) InsertBatch(ctx context.Context, entities []E, chunkSize int) error {
+	if len(entities) == 0 {
+		return nil
+	}
+	if chunkSize <= 0 {
+		chunkSize = len(entities)
+	}
+
+	models := make([]M, len(entities))
+	for i, e := range entities {
+		var start M
+		models[i] = start.FromEntity(e).(M)
+		if r.audit != nil {
+			models[i] = r.audit.BeforeCreate(ctx, models[i])
+		}
+	}
+
+	db := r.dbFor(ctx)
+	chunks := ChunkSlice(models, chunkSize)
+	for i, chunk := range chunks {
+		if err := db.CreateInBatches(&chunk, len(chunk)).Error; err != nil {
+			return fmt.Errorf("gorm_generics: insert chunk %d/%d failed: %w", i+1, len(chunks), err)
+		}
+	}
+
+	for i, m := range models {
+		entities[i] = m.ToEntity()
+	}
+	return nil
+}
+
+// UpsertBatch inserts entities in chunks of chunkSize, falling back to an
+// UPDATE of updateCols on any row that conflicts on conflictCols. It uses
+// clause.OnConflict under the hood, equivalent to Postgres/SQLite's
+// INSERT ... ON CONFLICT or MySQL's ON DUPLICATE KEY UPDATE. A chunkSize
+// <= 0 upserts everything in a single batch. Like InsertBatch, it runs the
+// repository's AuditHook.BeforeCreate (if any) over every entity first,
+// even for rows that end up taking the conflict/update path, since the
+// hook has no way to know ahead of time which rows will conflict.
+func (r *GormRepository[M, E]) UpsertBatch(ctx context.Context, entities []E, conflictCols []string, updateCols []string, chunkSize int) error {
+	if len(entities) == 0 {
+		return nil
+	}
+	if chunkSize <= 0 {
+		chunkSize = len(entities)
+	}
+
+	models := make([]M, len(entities))
+	for i, e := range entities {
+		var start M
+		models[i] = start.FromEntity(e).(M)
+		if r.audit != nil {
+			models[i] = r.audit.BeforeCreate(ctx, models[i])
+		}
+	}
+
+	conflictColumns := make([]clause.Column, len(conflictCols))
+	for i, c := range conflictCols {
+		conflictColumns[i] = clause.Column{Name: c}
+	}
+	onConflict := clause.OnConflict{
+		Columns:   conflictColumns,
+		DoUpdates: clause.AssignmentColumns(updateCols),
+	}
+
+	db := r.dbFor(ctx)
+	chunks := ChunkSlice(models, chunkSize)
+	for i, chunk := range chunks {
+		if err := db.Clauses(onConflict).CreateInBatches(&chunk, len(chunk)).Error; err != nil {
+			return fmt.Errorf("gorm_generics: upsert chunk %d/%d failed: %w", i+1, len(chunks), err)
+		}
+	}
+
+	for i, m := range models {
+		entities[i] = m.ToEntity()
+	}
+	return nil
+}
+
+// UpdateColumns updates only the given columns on every row matched by
+// specs, without loading the rows first, and reports how many rows were
+// touched.
+func (r *GormRepository[M, E]) UpdateColumns(ctx context.Context, values map[string]any, specs ...Specification) (int64, error) {
+	model := new(M)
+	result := applySpecs(r.dbFor(ctx), specs).Model(model).Updates(values)
+	return result.RowsAffected, result.Error
+}
+
+// DeleteWhere deletes every row matched by specs and reports how many rows
+// were removed. It scopes against plain dbFor rather than the repository's
+// SoftDeleteMode default: if that default were IncludeDeleted/OnlyDeleted,
+// the Unscoped() they imply would turn gorm's soft delete into a real
+// DELETE (see readDbFor's doc comment).
+func (r *GormRepository[M, E]) DeleteWhere(ctx context.Context, specs ...Specification) (int64, error) {
+	var model M
+	result := applySpecs(r.dbFor(ctx), specs).Delete(&model)
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,379 @@
+package gorm_generics
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CursorSigningKey, when non-empty, is used to HMAC-sign cursors returned by
+// FindByCursor so callers can detect tampering. Leave it empty to emit plain
+// (unsigned) cursors.
+var CursorSigningKey []byte
+
+// EndCursor is returned by FindByCursor once there are no more rows to page
+// through.
+const EndCursor = "end"
+
+type SortDirection string
+
+const (
+	SortAscending  SortDirection = "asc"
+	SortDescending SortDirection = "desc"
+)
+
+// SortField is a single column in a SortSpec.
+type SortField struct {
+	Column    string
+	Direction SortDirection
+}
+
+// SortSpec describes the ordered list of columns FindByCursor pages by. The
+// first field is the primary sort key; later fields break ties. If the
+// model's primary key is not already one of the fields it is appended
+// automatically so that every row has a unique position and the cursor can
+// never skip or repeat a row.
+type SortSpec struct {
+	Fields []SortField
+}
+
+func (s SortSpec) withPKTiebreaker(pkColumn string) SortSpec {
+	for _, f := range s.Fields {
+		if f.Column == pkColumn {
+			return s
+		}
+	}
+
+	dir := SortAscending
+	if len(s.Fields) > 0 {
+		dir = s.Fields[0].Direction
+	}
+
+	fields := make([]SortField, len(s.Fields), len(s.Fields)+1)
+	copy(fields, s.Fields)
+	fields = append(fields, SortField{Column: pkColumn, Direction: dir})
+	return SortSpec{Fields: fields}
+}
+
+// primaryKeyColumn resolves M's primary key column via gorm's schema
+// metadata, the same mechanism sortValuesForRow uses to resolve sort
+// columns, so the tiebreaker is correct for models with a custom PK column
+// instead of assuming "id".
+func primaryKeyColumn[M any](db *gorm.DB) (string, error) {
+	var model M
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(&model); err != nil {
+		return "", err
+	}
+	if len(stmt.Schema.PrimaryFieldDBNames) == 0 {
+		return "", fmt.Errorf("gorm_generics: model has no primary key")
+	}
+	return stmt.Schema.PrimaryFieldDBNames[0], nil
+}
+
+// signature uniquely identifies the columns and directions a cursor was
+// issued for, so a cursor minted against one SortSpec can be rejected if the
+// caller switches sort on a later call.
+func (s SortSpec) signature() string {
+	parts := make([]string, len(s.Fields))
+	for i, f := range s.Fields {
+		parts[i] = f.Column + ":" + string(f.Direction)
+	}
+	return strings.Join(parts, ",")
+}
+
+type cursorToken struct {
+	Sort   string        `json:"sort"`
+	Values []interface{} `json:"values"`
+	Sig    string        `json:"sig,omitempty"`
+}
+
+// UnmarshalJSON decodes numeric sort values with UseNumber instead of the
+// default float64, then converts each back to an int64 when it's exactly
+// representable as one. Plain json.Unmarshal into interface{} decodes every
+// JSON number as float64, which silently loses precision above 2^53 -
+// fatal for a cursor whose tiebreaker is typically a bigint primary key.
+func (t *cursorToken) UnmarshalJSON(data []byte) error {
+	type alias cursorToken
+	aux := (*alias)(t)
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(aux); err != nil {
+		return err
+	}
+
+	for i, v := range t.Values {
+		num, ok := v.(json.Number)
+		if !ok {
+			continue
+		}
+		if i64, err := num.Int64(); err == nil {
+			t.Values[i] = i64
+		} else if f64, err := num.Float64(); err == nil {
+			t.Values[i] = f64
+		}
+	}
+	return nil
+}
+
+func signCursor(raw []byte) string {
+	mac := hmac.New(sha256.New, CursorSigningKey)
+	mac.Write(raw)
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func encodeCursor(sig string, values []interface{}) (string, error) {
+	tok := cursorToken{Sort: sig, Values: values}
+	if len(CursorSigningKey) > 0 {
+		raw, err := json.Marshal(cursorToken{Sort: sig, Values: values})
+		if err != nil {
+			return "", err
+		}
+		tok.Sig = signCursor(raw)
+	}
+
+	raw, err := json.Marshal(tok)
+	if err != nil {
+		return "", err
+	}
+	return base64.URLEncoding.EncodeToString(raw), nil
+}
+
+func decodeCursor(cursor string, wantSig string) ([]interface{}, error) {
+	raw, err := base64.URLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("gorm_generics: invalid cursor: %w", err)
+	}
+
+	var tok cursorToken
+	if err := json.Unmarshal(raw, &tok); err != nil {
+		return nil, fmt.Errorf("gorm_generics: invalid cursor: %w", err)
+	}
+
+	if len(CursorSigningKey) > 0 {
+		signed := tok
+		signed.Sig = ""
+		signedRaw, err := json.Marshal(signed)
+		if err != nil {
+			return nil, err
+		}
+		if !hmac.Equal([]byte(signCursor(signedRaw)), []byte(tok.Sig)) {
+			return nil, fmt.Errorf("gorm_generics: cursor signature mismatch")
+		}
+	}
+
+	if tok.Sort != wantSig {
+		return nil, fmt.Errorf("gorm_generics: cursor was issued for sort %q, not %q", tok.Sort, wantSig)
+	}
+
+	return tok.Values, nil
+}
+
+// buildSeekClause returns the keyset ("seek") WHERE clause and its bind
+// values for advancing past the given row, e.g. for fields (a asc, b desc)
+// it produces:
+//
+//	(a > ?) OR (a = ? AND b < ?)
+func buildSeekClause(fields []SortField, values []interface{}) (string, []interface{}) {
+	var clauses []string
+	var args []interface{}
+
+	for i := range fields {
+		var parts []string
+		for j := 0; j < i; j++ {
+			parts = append(parts, fmt.Sprintf("%s = ?", fields[j].Column))
+			args = append(args, values[j])
+		}
+
+		op := ">"
+		if fields[i].Direction == SortDescending {
+			op = "<"
+		}
+		parts = append(parts, fmt.Sprintf("%s %s ?", fields[i].Column, op))
+		args = append(args, values[i])
+
+		clauses = append(clauses, "("+strings.Join(parts, " AND ")+")")
+	}
+
+	return strings.Join(clauses, " OR "), args
+}
+
+// sortValuesForRow extracts the value of each sort column from a model row
+// using gorm's schema metadata, in the order FindByCursor needs to mint the
+// next cursor.
+func sortValuesForRow[M any](db *gorm.DB, row M, fields []SortField) ([]interface{}, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(&row); err != nil {
+		return nil, err
+	}
+
+	rv := reflect.ValueOf(row)
+	values := make([]interface{}, len(fields))
+	for i, f := range fields {
+		field := stmt.Schema.LookUpField(f.Column)
+		if field == nil {
+			return nil, fmt.Errorf("gorm_generics: unknown sort column %q", f.Column)
+		}
+		value, _ := field.ValueOf(context.Background(), rv)
+		values[i] = value
+	}
+
+	return values, nil
+}
+
+// retypeCursorValues converts each value decoded off a cursor back to the Go
+// type gorm expects for its column, looked up via schema the same way
+// sortValuesForRow does. JSON can only round-trip strings, numbers, bools,
+// and nil: a time.Time sort column survives encodeCursor (time.Time has a
+// MarshalJSON producing an RFC3339 string) but comes back out of
+// decodeCursor as a plain string, which then fails to match anything when
+// bound against a timestamp column. Retyping here, once, before the value
+// is used to build the seek clause, fixes that for time.Time and for any
+// other column whose Go type isn't a JSON string/float64 (e.g. an int32
+// PK decoded as int64).
+func retypeCursorValues[M any](db *gorm.DB, fields []SortField, values []interface{}) ([]interface{}, error) {
+	var model M
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(&model); err != nil {
+		return nil, err
+	}
+
+	out := make([]interface{}, len(values))
+	for i, v := range values {
+		field := stmt.Schema.LookUpField(fields[i].Column)
+		if field == nil {
+			return nil, fmt.Errorf("gorm_generics: unknown sort column %q", fields[i].Column)
+		}
+		converted, err := retypeCursorValue(v, field.FieldType)
+		if err != nil {
+			return nil, fmt.Errorf("gorm_generics: cursor value for %q: %w", fields[i].Column, err)
+		}
+		out[i] = converted
+	}
+	return out, nil
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// retypeCursorValue converts v, as decoded from cursor JSON, to t.
+func retypeCursorValue(v interface{}, t reflect.Type) (interface{}, error) {
+	if v == nil {
+		return v, nil
+	}
+
+	if t.Kind() == reflect.Ptr {
+		converted, err := retypeCursorValue(v, t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		ptr := reflect.New(t.Elem())
+		ptr.Elem().Set(reflect.ValueOf(converted))
+		return ptr.Interface(), nil
+	}
+
+	if t == timeType {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected an RFC3339 string for a time.Time column, got %T", v)
+		}
+		return time.Parse(time.RFC3339Nano, s)
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Type() == t || !rv.Type().ConvertibleTo(t) {
+		return v, nil
+	}
+	return rv.Convert(t).Interface(), nil
+}
+
+// FindByCursor performs opaque, keyset-based pagination: instead of
+// OFFSET/LIMIT (which degrades to O(N) and can skip or repeat rows when the
+// table is written to concurrently) it seeks past the last row of the
+// previous page using a WHERE (sort_col, id) > (?, ?) style predicate. Pass
+// an empty cursor for the first page. The returned nextCursor is EndCursor
+// once there are no more rows.
+//
+// sort must be the same SortSpec across calls for a given cursor chain;
+// FindByCursor rejects a cursor minted under a different sort so callers
+// can't silently skip or duplicate rows by switching sort mid-stream.
+//
+// opts is applied the same way as on Find/FindPaged, so callers can pass
+// WithPreload/WithSelect/WithLock/etc. alongside the cursor.
+func (r *GormRepository[M, E]) FindByCursor(ctx context.Context, cursor string, pageSize int, sort SortSpec, opts []DBOption, specifications ...Specification) ([]E, string, error) {
+	if pageSize <= 0 {
+		pageSize = 1
+	}
+
+	pkColumn, err := primaryKeyColumn[M](r.db)
+	if err != nil {
+		return nil, "", err
+	}
+	sort = sort.withPKTiebreaker(pkColumn)
+	sig := sort.signature()
+
+	dbPrewarm := r.getPreWarmDbForSelect(ctx, opts, specifications...)
+
+	if cursor != "" && cursor != EndCursor {
+		values, err := decodeCursor(cursor, sig)
+		if err != nil {
+			return nil, "", err
+		}
+		if len(values) != len(sort.Fields) {
+			return nil, "", fmt.Errorf("gorm_generics: cursor has %d values but sort has %d fields", len(values), len(sort.Fields))
+		}
+
+		values, err = retypeCursorValues[M](r.db, sort.Fields, values)
+		if err != nil {
+			return nil, "", err
+		}
+
+		seekClause, args := buildSeekClause(sort.Fields, values)
+		dbPrewarm = dbPrewarm.Where(seekClause, args...)
+	}
+
+	for _, f := range sort.Fields {
+		dir := "ASC"
+		if f.Direction == SortDescending {
+			dir = "DESC"
+		}
+		dbPrewarm = dbPrewarm.Order(f.Column + " " + dir)
+	}
+
+	var models []M
+	if err := dbPrewarm.Limit(pageSize + 1).Find(&models).Error; err != nil {
+		return nil, "", err
+	}
+
+	hasMore := len(models) > pageSize
+	if hasMore {
+		models = models[:pageSize]
+	}
+
+	items := r.FromModelToDto(models)
+
+	if !hasMore || len(models) == 0 {
+		return items, EndCursor, nil
+	}
+
+	values, err := sortValuesForRow(r.db, models[len(models)-1], sort.Fields)
+	if err != nil {
+		return nil, "", err
+	}
+
+	nextCursor, err := encodeCursor(sig, values)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return items, nextCursor, nil
+}
@@ -0,0 +1,89 @@
+package gorm_generics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type cursorTestModel struct {
+	ID        int64 `gorm:"primaryKey"`
+	CreatedAt time.Time
+}
+
+func (m cursorTestModel) ToEntity() cursorTestEntity {
+	return cursorTestEntity{ID: m.ID, CreatedAt: m.CreatedAt}
+}
+
+func (m cursorTestModel) FromEntity(e cursorTestEntity) interface{} {
+	return cursorTestModel{ID: e.ID, CreatedAt: e.CreatedAt}
+}
+
+type cursorTestEntity struct {
+	ID        int64
+	CreatedAt time.Time
+}
+
+// TestFindByCursorPagesByTimeColumn is a DB-backed regression test (sqlite
+// in-memory) for paging by a time.Time sort column end-to-end. Without
+// retypeCursorValues, the cursor's created_at value comes back out of JSON
+// as a plain RFC3339 string instead of a time.Time, and
+// "created_at > ?" bound against that string matches zero rows on the
+// second call - so this only passes if the seek clause is bound with a
+// real time.Time.
+func TestFindByCursorPagesByTimeColumn(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&cursorTestModel{}); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := int64(1); i <= 5; i++ {
+		row := cursorTestModel{ID: i, CreatedAt: base.Add(time.Duration(i) * time.Hour)}
+		if err := db.Create(&row).Error; err != nil {
+			t.Fatalf("seed row %d: %v", i, err)
+		}
+	}
+
+	repo := NewRepository[cursorTestModel, cursorTestEntity](db)
+	sort := SortSpec{Fields: []SortField{{Column: "created_at", Direction: SortAscending}}}
+
+	first, cursor, err := repo.FindByCursor(context.Background(), "", 2, sort, nil)
+	if err != nil {
+		t.Fatalf("first page: %v", err)
+	}
+	if len(first) != 2 {
+		t.Fatalf("first page len = %d, want 2", len(first))
+	}
+	if cursor == "" || cursor == EndCursor {
+		t.Fatalf("expected a continuation cursor, got %q", cursor)
+	}
+
+	second, cursor2, err := repo.FindByCursor(context.Background(), cursor, 2, sort, nil)
+	if err != nil {
+		t.Fatalf("second page: %v", err)
+	}
+	if len(second) != 2 {
+		t.Fatalf("second page len = %d, want 2 (paging by created_at silently returned 0 rows)", len(second))
+	}
+	if second[0].ID == first[0].ID || second[0].ID == first[1].ID {
+		t.Fatalf("second page repeated a row from the first page: %+v", second)
+	}
+
+	third, cursor3, err := repo.FindByCursor(context.Background(), cursor2, 2, sort, nil)
+	if err != nil {
+		t.Fatalf("third page: %v", err)
+	}
+	if len(third) != 1 {
+		t.Fatalf("third page len = %d, want 1", len(third))
+	}
+	if cursor3 != EndCursor {
+		t.Fatalf("cursor3 = %q, want EndCursor", cursor3)
+	}
+}
@@ -0,0 +1,133 @@
+package gorm_generics
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSortSpecWithPKTiebreakerAppendsOnce(t *testing.T) {
+	sort := SortSpec{Fields: []SortField{{Column: "created_at", Direction: SortDescending}}}
+
+	withPK := sort.withPKTiebreaker("id")
+	if len(withPK.Fields) != 2 || withPK.Fields[1].Column != "id" {
+		t.Fatalf("expected id to be appended as tiebreaker, got %+v", withPK.Fields)
+	}
+	if withPK.Fields[1].Direction != SortDescending {
+		t.Fatalf("expected tiebreaker to inherit primary sort direction, got %s", withPK.Fields[1].Direction)
+	}
+
+	// Already present (e.g. a custom PK column used explicitly) -> no-op.
+	already := SortSpec{Fields: []SortField{{Column: "uuid", Direction: SortAscending}}}
+	withPK2 := already.withPKTiebreaker("uuid")
+	if len(withPK2.Fields) != 1 {
+		t.Fatalf("expected no duplicate tiebreaker, got %+v", withPK2.Fields)
+	}
+}
+
+func TestSortSpecSignatureChangesWithFields(t *testing.T) {
+	a := SortSpec{Fields: []SortField{{Column: "created_at", Direction: SortDescending}, {Column: "id", Direction: SortDescending}}}
+	b := SortSpec{Fields: []SortField{{Column: "created_at", Direction: SortAscending}, {Column: "id", Direction: SortDescending}}}
+
+	if a.signature() == b.signature() {
+		t.Fatalf("expected differing sort directions to produce differing signatures")
+	}
+	if a.signature() != a.signature() {
+		t.Fatalf("signature must be deterministic")
+	}
+}
+
+func TestBuildSeekClauseCompoundSort(t *testing.T) {
+	fields := []SortField{
+		{Column: "created_at", Direction: SortDescending},
+		{Column: "id", Direction: SortAscending},
+	}
+	values := []interface{}{"2026-01-01", int64(42)}
+
+	clause, args := buildSeekClause(fields, values)
+
+	const want = "(created_at < ?) OR (created_at = ? AND id > ?)"
+	if clause != want {
+		t.Fatalf("clause = %q, want %q", clause, want)
+	}
+	if len(args) != 3 || args[0] != "2026-01-01" || args[1] != "2026-01-01" || args[2] != int64(42) {
+		t.Fatalf("unexpected args: %#v", args)
+	}
+}
+
+func TestEncodeDecodeCursorRoundTrip(t *testing.T) {
+	sig := SortSpec{Fields: []SortField{{Column: "id", Direction: SortAscending}}}.signature()
+
+	cursor, err := encodeCursor(sig, []interface{}{int64(7)})
+	if err != nil {
+		t.Fatalf("encodeCursor failed: %v", err)
+	}
+
+	values, err := decodeCursor(cursor, sig)
+	if err != nil {
+		t.Fatalf("decodeCursor failed: %v", err)
+	}
+	if len(values) != 1 || values[0] != int64(7) {
+		t.Fatalf("values = %#v, want [int64(7)]", values)
+	}
+}
+
+func TestEncodeDecodeCursorPreservesInt64Precision(t *testing.T) {
+	const bigID int64 = 9007199254740993 // 2^53 + 1, the classic float64 rounding boundary
+	sig := SortSpec{Fields: []SortField{{Column: "id", Direction: SortAscending}}}.signature()
+
+	cursor, err := encodeCursor(sig, []interface{}{bigID})
+	if err != nil {
+		t.Fatalf("encodeCursor failed: %v", err)
+	}
+
+	values, err := decodeCursor(cursor, sig)
+	if err != nil {
+		t.Fatalf("decodeCursor failed: %v", err)
+	}
+
+	got, ok := values[0].(int64)
+	if !ok {
+		t.Fatalf("values[0] = %#v (%T), want int64", values[0], values[0])
+	}
+	if got != bigID {
+		t.Fatalf("values[0] = %d, want %d (precision lost)", got, bigID)
+	}
+}
+
+func TestDecodeCursorRejectsSortMismatch(t *testing.T) {
+	sigA := SortSpec{Fields: []SortField{{Column: "id", Direction: SortAscending}}}.signature()
+	sigB := SortSpec{Fields: []SortField{{Column: "created_at", Direction: SortDescending}}}.signature()
+
+	cursor, err := encodeCursor(sigA, []interface{}{int64(1)})
+	if err != nil {
+		t.Fatalf("encodeCursor failed: %v", err)
+	}
+
+	if _, err := decodeCursor(cursor, sigB); err == nil {
+		t.Fatalf("expected decodeCursor to reject a cursor minted under a different sort")
+	}
+}
+
+func TestEncodeDecodeCursorSigned(t *testing.T) {
+	CursorSigningKey = []byte("test-signing-key")
+	t.Cleanup(func() { CursorSigningKey = nil })
+
+	sig := SortSpec{Fields: []SortField{{Column: "id", Direction: SortAscending}}}.signature()
+
+	cursor, err := encodeCursor(sig, []interface{}{int64(1)})
+	if err != nil {
+		t.Fatalf("encodeCursor failed: %v", err)
+	}
+
+	if _, err := decodeCursor(cursor, sig); err != nil {
+		t.Fatalf("decodeCursor rejected a validly signed cursor: %v", err)
+	}
+
+	tampered := strings.Replace(cursor, "a", "b", 1)
+	if tampered == cursor {
+		t.Skip("cursor had no 'a' to tamper with")
+	}
+	if _, err := decodeCursor(tampered, sig); err == nil {
+		t.Fatalf("expected decodeCursor to reject a tampered signed cursor")
+	}
+}
@@ -0,0 +1,115 @@
+package gorm_generics
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DBOption mutates a *gorm.DB before a GormRepository method issues its
+// query, letting callers compose preloads, selects, locks, joins, and the
+// like without dropping down to raw gorm.
+type DBOption func(*gorm.DB) *gorm.DB
+
+// WithPreload eager-loads the given association, e.g. WithPreload("Orders")
+// or WithPreload(clause.Associations) to load them all.
+func WithPreload(assoc string, args ...any) DBOption {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Preload(assoc, args...)
+	}
+}
+
+// WithOmitAssociations skips writing the model's associations, useful on
+// Insert/Update calls that should only touch the base table.
+func WithOmitAssociations() DBOption {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Omit(clause.Associations)
+	}
+}
+
+// WithSelect restricts the columns returned by a read.
+func WithSelect(cols ...string) DBOption {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Select(cols)
+	}
+}
+
+// WithOrder appends an ORDER BY clause, e.g. WithOrder("created_at desc").
+func WithOrder(expr string) DBOption {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Order(expr)
+	}
+}
+
+// WithLock applies a row-level locking clause, e.g. WithLock("UPDATE") for
+// SELECT ... FOR UPDATE.
+func WithLock(strength string) DBOption {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Clauses(clause.Locking{Strength: strength})
+	}
+}
+
+// WithJoins adds a join expression, e.g. WithJoins("JOIN accounts ON accounts.id = users.account_id").
+func WithJoins(expr string, args ...any) DBOption {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Joins(expr, args...)
+	}
+}
+
+func applyOptions(db *gorm.DB, opts []DBOption) *gorm.DB {
+	for _, opt := range opts {
+		db = opt(db)
+	}
+	return db
+}
+
+// txKey is the context key WithTx stashes the enrolled *gorm.DB under so
+// nested repository calls sharing the same ctx automatically join the
+// transaction instead of opening their own.
+type txKey struct{}
+
+// getTx returns the *gorm.DB enrolled in ctx by WithTx, if any.
+func getTx(ctx context.Context) (*gorm.DB, bool) {
+	tx, ok := ctx.Value(txKey{}).(*gorm.DB)
+	return tx, ok
+}
+
+// dbFor returns the *gorm.DB a method should issue its query against: the
+// transaction stashed in ctx by WithTx when present, otherwise the
+// repository's own db scoped to ctx.
+func (r *GormRepository[M, E]) dbFor(ctx context.Context) *gorm.DB {
+	if tx, ok := getTx(ctx); ok {
+		return tx
+	}
+	return r.db.WithContext(ctx)
+}
+
+// readDbFor is dbFor with the repository's default SoftDeleteMode applied.
+// It must only be used for reads: Unscoped() here is safe because nothing
+// downstream is a Delete call. Reusing it for a write would turn gorm's
+// soft-delete (UPDATE ... SET deleted_at) into a real DELETE, since gorm
+// only rewrites DELETE into a soft delete when the statement isn't
+// Unscoped.
+func (r *GormRepository[M, E]) readDbFor(ctx context.Context) *gorm.DB {
+	db := r.dbFor(ctx)
+	switch r.softDeleteMode {
+	case IncludeDeleted:
+		return WithTrashed()(db)
+	case OnlyDeleted:
+		return WithOnlyTrashed[M]()(db)
+	default:
+		return db
+	}
+}
+
+// WithTx runs fn inside a database transaction. The transaction is stashed
+// in the context passed to fn, so any GormRepository method called with
+// that context (including on other repositories sharing the same *gorm.DB)
+// automatically enrolls in the same transaction. Returning an error from fn
+// rolls the transaction back; returning nil commits it.
+func (r *GormRepository[M, E]) WithTx(ctx context.Context, fn func(txCtx context.Context) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
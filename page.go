@@ -0,0 +1,65 @@
+package gorm_generics
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// PageMode selects how a page of results is produced.
+type PageMode int
+
+const (
+	// PageModeOffset pages with LIMIT/OFFSET, as served by FindPagedWithLimit.
+	PageModeOffset PageMode = iota
+	// PageModeCursor pages with a keyset cursor, as served by FindByCursor.
+	PageModeCursor
+)
+
+// PageResult is the result of an offset-paged query. Page is 1-indexed.
+type PageResult[E any] struct {
+	Data       []E   `json:"data"`
+	TotalItems int64 `json:"totalItems"`
+	TotalPages int64 `json:"totalPages"`
+	Page       int   `json:"page"`
+	Size       int   `json:"size"`
+	HasNext    bool  `json:"hasNext"`
+}
+
+// WriteHeaders writes pagination metadata as response headers: X-Total-Count,
+// X-Page, X-Page-Count, and an RFC 5988 Link header with first/prev/next/last
+// relations. Each link keeps baseURL's other query parameters and sets its
+// "page" parameter; the query string is re-encoded with keys in sorted
+// order. If baseURL does not parse, it is used unchanged for every link.
+func (p PageResult[E]) WriteHeaders(w http.ResponseWriter, baseURL string) {
+	w.Header().Set("X-Total-Count", strconv.FormatInt(p.TotalItems, 10))
+	w.Header().Set("X-Page", strconv.Itoa(p.Page))
+	w.Header().Set("X-Page-Count", strconv.FormatInt(p.TotalPages, 10))
+
+	pageURL := func(page int) string {
+		u, err := url.Parse(baseURL)
+		if err != nil {
+			return baseURL
+		}
+		q := u.Query()
+		q.Set("page", strconv.Itoa(page))
+		u.RawQuery = q.Encode()
+		return u.String()
+	}
+
+	var links []string
+	links = append(links, fmt.Sprintf(`<%s>; rel="first"`, pageURL(1)))
+	if p.Page > 1 {
+		links = append(links, fmt.Sprintf(`<%s>; rel="prev"`, pageURL(p.Page-1)))
+	}
+	if p.HasNext {
+		links = append(links, fmt.Sprintf(`<%s>; rel="next"`, pageURL(p.Page+1)))
+	}
+	if p.TotalPages > 0 {
+		links = append(links, fmt.Sprintf(`<%s>; rel="last"`, pageURL(int(p.TotalPages))))
+	}
+
+	w.Header().Set("Link", strings.Join(links, ", "))
+}
@@ -0,0 +1,64 @@
+package gorm_generics
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWriteHeadersOnlyPage(t *testing.T) {
+	p := PageResult[int]{Page: 1, Size: 10, TotalItems: 3, TotalPages: 1, HasNext: false}
+
+	rec := httptest.NewRecorder()
+	p.WriteHeaders(rec, "https://api.example.com/items")
+
+	if got := rec.Header().Get("X-Total-Count"); got != "3" {
+		t.Fatalf("X-Total-Count = %q, want %q", got, "3")
+	}
+	if got := rec.Header().Get("X-Page"); got != "1" {
+		t.Fatalf("X-Page = %q, want %q", got, "1")
+	}
+	if got := rec.Header().Get("X-Page-Count"); got != "1" {
+		t.Fatalf("X-Page-Count = %q, want %q", got, "1")
+	}
+
+	const want = `<https://api.example.com/items?page=1>; rel="first", <https://api.example.com/items?page=1>; rel="last"`
+	if got := rec.Header().Get("Link"); got != want {
+		t.Fatalf("Link = %q, want %q", got, want)
+	}
+}
+
+func TestWriteHeadersMiddlePage(t *testing.T) {
+	p := PageResult[int]{Page: 2, Size: 10, TotalItems: 30, TotalPages: 3, HasNext: true}
+
+	rec := httptest.NewRecorder()
+	p.WriteHeaders(rec, "https://api.example.com/items")
+
+	const want = `<https://api.example.com/items?page=1>; rel="first", <https://api.example.com/items?page=1>; rel="prev", <https://api.example.com/items?page=3>; rel="next", <https://api.example.com/items?page=3>; rel="last"`
+	if got := rec.Header().Get("Link"); got != want {
+		t.Fatalf("Link = %q, want %q", got, want)
+	}
+}
+
+func TestWriteHeadersLastPage(t *testing.T) {
+	p := PageResult[int]{Page: 3, Size: 10, TotalItems: 30, TotalPages: 3, HasNext: false}
+
+	rec := httptest.NewRecorder()
+	p.WriteHeaders(rec, "https://api.example.com/items")
+
+	const want = `<https://api.example.com/items?page=1>; rel="first", <https://api.example.com/items?page=2>; rel="prev", <https://api.example.com/items?page=3>; rel="last"`
+	if got := rec.Header().Get("Link"); got != want {
+		t.Fatalf("Link = %q, want %q", got, want)
+	}
+}
+
+func TestWriteHeadersPreservesExistingQuery(t *testing.T) {
+	p := PageResult[int]{Page: 1, Size: 10, TotalItems: 0, TotalPages: 0, HasNext: false}
+
+	rec := httptest.NewRecorder()
+	p.WriteHeaders(rec, "https://api.example.com/items?status=active")
+
+	const want = `<https://api.example.com/items?page=1&status=active>; rel="first"`
+	if got := rec.Header().Get("Link"); got != want {
+		t.Fatalf("Link = %q, want %q", got, want)
+	}
+}
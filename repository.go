@@ -2,10 +2,10 @@ package gorm_generics
 
 import (
 	"context"
+	"fmt"
 	"math"
 
 	"gorm.io/gorm"
-	"gorm.io/gorm/clause"
 )
 
 type GormModel[E any] interface {
@@ -13,12 +13,7 @@ type GormModel[E any] interface {
 	FromEntity(entity E) interface{}
 }
 
-type PageResult[M GormModel[E], E any] struct {
-	Data  []M   `json:"data"`
-	Count int64 `json:"count"`
-	Page  int   `json:"page"`
-}
-
+// PageConfig.Page is 1-indexed: page 1 is the first page.
 type PageConfig struct {
 	Page int   `json:"page"`
 	Size int64 `json:"size"`
@@ -27,6 +22,10 @@ type PageConfig struct {
 	IgnoreCount bool `json:"IngoreCount"`
 	// if you want the count to always be returned.
 	ForceCount bool `json:"ForceCount"`
+	// Mode picks between offset-based paging (served by FindPagedWithLimit)
+	// and keyset/cursor paging (served by FindByCursor). It defaults to
+	// PageModeOffset so existing callers are unaffected.
+	Mode PageMode `json:"mode"`
 }
 
 func NewRepository[M GormModel[E], E any](db *gorm.DB) *GormRepository[M, E] {
@@ -36,14 +35,36 @@ func NewRepository[M GormModel[E], E any](db *gorm.DB) *GormRepository[M, E] {
 }
 
 type GormRepository[M GormModel[E], E any] struct {
-	db *gorm.DB
+	db             *gorm.DB
+	audit          AuditHook[M]
+	softDeleteMode SoftDeleteMode
+}
+
+// SetAuditHook installs the hook the repository calls to stamp audit
+// columns on Insert/Update. Pass nil to remove it.
+func (r *GormRepository[M, E]) SetAuditHook(hook AuditHook[M]) {
+	r.audit = hook
 }
 
-func (r *GormRepository[M, E]) Insert(ctx context.Context, entity *E) error {
+// SetSoftDeleteMode changes the repository's default treatment of
+// soft-deleted rows from HideDeleted to IncludeDeleted or OnlyDeleted for
+// every subsequent read. WithTrashed/WithOnlyTrashed are applied on top of
+// the default for a single call: they widen HideDeleted or narrow
+// IncludeDeleted, but under OnlyDeleted the deleted-rows filter stays in
+// place even with WithTrashed, and no option restores HideDeleted for a
+// single call.
+func (r *GormRepository[M, E]) SetSoftDeleteMode(mode SoftDeleteMode) {
+	r.softDeleteMode = mode
+}
+
+func (r *GormRepository[M, E]) Insert(ctx context.Context, entity *E, opts ...DBOption) error {
 	var start M
 	model := start.FromEntity(*entity).(M)
+	if r.audit != nil {
+		model = r.audit.BeforeCreate(ctx, model)
+	}
 
-	err := r.db.WithContext(ctx).Create(&model).Error
+	err := applyOptions(r.dbFor(ctx), opts).Create(&model).Error
 	if err != nil {
 		return err
 	}
@@ -52,35 +73,35 @@ func (r *GormRepository[M, E]) Insert(ctx context.Context, entity *E) error {
 	return nil
 }
 
-func (r *GormRepository[M, E]) InsertDirect(ctx context.Context, entity *M) error {
-	err := r.db.WithContext(ctx).Create(&entity).Error
+func (r *GormRepository[M, E]) InsertDirect(ctx context.Context, entity *M, opts ...DBOption) error {
+	err := applyOptions(r.dbFor(ctx), opts).Create(&entity).Error
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (r *GormRepository[M, E]) InsertFromInterface(ctx context.Context, data interface{}) error {
-	err := r.db.WithContext(ctx).Create(&data).Error
+func (r *GormRepository[M, E]) InsertFromInterface(ctx context.Context, data interface{}, opts ...DBOption) error {
+	err := applyOptions(r.dbFor(ctx), opts).Create(&data).Error
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (r *GormRepository[M, E]) Delete(ctx context.Context, entity *E) error {
+func (r *GormRepository[M, E]) Delete(ctx context.Context, entity *E, opts ...DBOption) error {
 	var start M
 	model := start.FromEntity(*entity).(M)
-	err := r.db.WithContext(ctx).Delete(model).Error
+	err := applyOptions(r.dbFor(ctx), opts).Delete(model).Error
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (r *GormRepository[M, E]) DeleteById(ctx context.Context, id any) error {
+func (r *GormRepository[M, E]) DeleteById(ctx context.Context, id any, opts ...DBOption) error {
 	var start M
-	err := r.db.WithContext(ctx).Delete(&start, &id).Error
+	err := applyOptions(r.dbFor(ctx), opts).Delete(&start, &id).Error
 	if err != nil {
 		return err
 	}
@@ -88,11 +109,14 @@ func (r *GormRepository[M, E]) DeleteById(ctx context.Context, id any) error {
 	return nil
 }
 
-func (r *GormRepository[M, E]) Update(ctx context.Context, entity *E) error {
+func (r *GormRepository[M, E]) Update(ctx context.Context, entity *E, opts ...DBOption) error {
 	var start M
 	model := start.FromEntity(*entity).(M)
+	if r.audit != nil {
+		model = r.audit.BeforeUpdate(ctx, model)
+	}
 
-	err := r.db.WithContext(ctx).Save(&model).Error
+	err := applyOptions(r.dbFor(ctx), opts).Save(&model).Error
 	if err != nil {
 		return err
 	}
@@ -101,27 +125,17 @@ func (r *GormRepository[M, E]) Update(ctx context.Context, entity *E) error {
 	return nil
 }
 
-func (r *GormRepository[M, E]) UpdateDirect(ctx context.Context, entity *M) error {
-	err := r.db.WithContext(ctx).Save(&entity).Error
+func (r *GormRepository[M, E]) UpdateDirect(ctx context.Context, entity *M, opts ...DBOption) error {
+	err := applyOptions(r.dbFor(ctx), opts).Save(&entity).Error
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (r *GormRepository[M, E]) FindByID(ctx context.Context, id any) (E, error) {
-	var model M
-	err := r.db.WithContext(ctx).First(&model, id).Error
-	if err != nil {
-		return *new(E), err
-	}
-
-	return model.ToEntity(), nil
-}
-
-func (r *GormRepository[M, E]) FindByIDWithOptions(ctx context.Context, id any, eagerLoad bool) (E, error) {
+func (r *GormRepository[M, E]) FindByID(ctx context.Context, id any, opts ...DBOption) (E, error) {
 	var model M
-	err := r.db.WithContext(ctx).Preload(clause.Associations).First(&model, id).Error
+	err := applyOptions(r.readDbFor(ctx), opts).First(&model, id).Error
 	if err != nil {
 		return *new(E), err
 	}
@@ -129,9 +143,9 @@ func (r *GormRepository[M, E]) FindByIDWithOptions(ctx context.Context, id any,
 	return model.ToEntity(), nil
 }
 
-func (r *GormRepository[M, E]) FindByModel(ctx context.Context, entity *M) (M, error) {
+func (r *GormRepository[M, E]) FindByModel(ctx context.Context, entity *M, opts ...DBOption) (M, error) {
 	var model M
-	err := r.db.WithContext(ctx).Preload(clause.Associations).Where(entity).First(&model).Error
+	err := applyOptions(r.readDbFor(ctx), opts).Where(entity).First(&model).Error
 	if err != nil {
 		return *new(M), err
 	}
@@ -139,42 +153,78 @@ func (r *GormRepository[M, E]) FindByModel(ctx context.Context, entity *M) (M, e
 	return model, err
 }
 
-func (r *GormRepository[M, E]) FindByModelMulti(ctx context.Context, entity *M) ([]M, error) {
+func (r *GormRepository[M, E]) FindByModelMulti(ctx context.Context, entity *M, opts ...DBOption) ([]M, error) {
 	var models []M
 
-	result := r.db.Where(&entity).Find(&models)
+	result := applyOptions(r.readDbFor(ctx), opts).Where(&entity).Find(&models)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 	return models, nil
 }
 
-func (r *GormRepository[M, E]) Find(ctx context.Context, specifications ...Specification) ([]E, error) {
-	return r.FindWithLimit(ctx, -1, -1, specifications...)
+func (r *GormRepository[M, E]) Find(ctx context.Context, opts []DBOption, specifications ...Specification) ([]E, error) {
+	return r.FindWithLimit(ctx, -1, -1, opts, specifications...)
 }
 
-func (r *GormRepository[M, E]) FindPaged(ctx context.Context, specifications ...Specification) ([]E, error) {
-	return r.FindWithLimit(ctx, -1, -1, specifications...)
+func (r *GormRepository[M, E]) FindPaged(ctx context.Context, opts []DBOption, specifications ...Specification) ([]E, error) {
+	return r.FindWithLimit(ctx, -1, -1, opts, specifications...)
 }
 
-func (r *GormRepository[M, E]) Count(ctx context.Context, specifications ...Specification) (i int64, err error) {
+func (r *GormRepository[M, E]) Count(ctx context.Context, opts []DBOption, specifications ...Specification) (i int64, err error) {
 	model := new(M)
-	err = r.getPreWarmDbForSelect(ctx, specifications...).Model(model).Count(&i).Error
+	err = r.getPreWarmDbForSelect(ctx, opts, specifications...).Model(model).Count(&i).Error
 	return
 }
 
-func (r *GormRepository[M, E]) getPreWarmDbForSelect(ctx context.Context, specification ...Specification) *gorm.DB {
-	var dbPrewarm *gorm.DB = r.db.WithContext(ctx)
+// applySpecs folds specification onto db, dispatching each spec by its
+// SpecKind. It's shared by read paths (via getPreWarmDbForSelect, scoped
+// with readDbFor) and the bulk write paths in batch.go (scoped with plain
+// dbFor), since the dispatch logic itself doesn't care which scope it's
+// layered onto.
+func applySpecs(db *gorm.DB, specification []Specification) *gorm.DB {
 	for _, s := range specification {
-		dbPrewarm = dbPrewarm.Where(s.GetQuery(), s.GetValues()...)
+		kind := KindWhere
+		if qs, ok := s.(QuerySpec); ok {
+			kind = qs.Kind()
+		}
+
+		switch kind {
+		case KindOrder:
+			db = db.Order(s.GetQuery())
+		case KindGroup:
+			db = db.Group(s.GetQuery())
+		case KindHaving:
+			db = db.Having(s.GetQuery(), s.GetValues()...)
+		case KindJoin:
+			db = db.Joins(s.GetQuery(), s.GetValues()...)
+		case KindPreload:
+			if ps, ok := s.(preloadSpec); ok && len(ps.Nested()) > 0 {
+				nested := ps.Nested()
+				db = db.Preload(s.GetQuery(), func(tx *gorm.DB) *gorm.DB {
+					for _, n := range nested {
+						tx = tx.Where(n.GetQuery(), n.GetValues()...)
+					}
+					return tx
+				})
+			} else {
+				db = db.Preload(s.GetQuery())
+			}
+		default:
+			db = db.Where(s.GetQuery(), s.GetValues()...)
+		}
 	}
-	return dbPrewarm
+	return db
+}
+
+func (r *GormRepository[M, E]) getPreWarmDbForSelect(ctx context.Context, opts []DBOption, specification ...Specification) *gorm.DB {
+	return applySpecs(applyOptions(r.readDbFor(ctx), opts), specification)
 }
 
-func (r *GormRepository[M, E]) FindWithLimit(ctx context.Context, limit int, offset int, specifications ...Specification) ([]E, error) {
+func (r *GormRepository[M, E]) FindWithLimit(ctx context.Context, limit int, offset int, opts []DBOption, specifications ...Specification) ([]E, error) {
 	var models []M
 
-	dbPrewarm := r.getPreWarmDbForSelect(ctx, specifications...)
+	dbPrewarm := r.getPreWarmDbForSelect(ctx, opts, specifications...)
 	err := dbPrewarm.Limit(limit).Offset(offset).Find(&models).Error
 
 	if err != nil {
@@ -189,59 +239,67 @@ func (r *GormRepository[M, E]) FindWithLimit(ctx context.Context, limit int, off
 	return result, nil
 }
 
-func (r *GormRepository[M, E]) FindPagedWithLimit(ctx context.Context, pageCfg PageConfig, specifications ...Specification) (PageResult[M, E], error) {
+func (r *GormRepository[M, E]) FindPagedWithLimit(ctx context.Context, pageCfg PageConfig, opts []DBOption, specifications ...Specification) (PageResult[E], error) {
 	var models []M
-	dbPrewarm := r.getPreWarmDbForSelect(ctx, specifications...)
 
-	//If page is 0 do the count
-	rs := PageResult[M, E]{
-		Count: 0,
-		Page:  pageCfg.Page,
+	page := pageCfg.Page
+	if page < 1 {
+		page = 1
+	}
+	size := int(math.Max(1, float64(pageCfg.Size)))
+
+	//If page is 1 do the count
+	rs := PageResult[E]{
+		Page: page,
+		Size: size,
 	}
 
-	minLimit := math.Max(1, float64(pageCfg.Size))
-	shouldCount := pageCfg.ForceCount || (pageCfg.Page == 0 && !pageCfg.IgnoreCount)
+	if pageCfg.Mode == PageModeCursor {
+		return rs, fmt.Errorf("gorm_generics: PageConfig.Mode is PageModeCursor; call FindByCursor instead of FindPagedWithLimit")
+	}
+
+	dbPrewarm := r.getPreWarmDbForSelect(ctx, opts, specifications...)
+
+	shouldCount := pageCfg.ForceCount || (page == 1 && !pageCfg.IgnoreCount)
 
 	if shouldCount {
 		model := new(M)
 
-		var elementCount int64 = 0
-		er := dbPrewarm.Model(model).Count(&elementCount)
+		var totalItems int64 = 0
+		er := dbPrewarm.Model(model).Count(&totalItems)
 
 		if er.Error != nil {
 			return rs, er.Error
 		}
-		rs.Count = int64(math.Ceil(float64(elementCount) / float64(minLimit)))
+		rs.TotalItems = totalItems
+		rs.TotalPages = int64(math.Ceil(float64(totalItems) / float64(size)))
 	}
 
-	err := dbPrewarm.Limit(int(minLimit)).Offset(pageCfg.Page).Find(&models).Error
+	// Fetch one extra row so HasNext reflects whether another page actually
+	// exists, rather than guessing from whether this page came back full
+	// (which is wrong whenever total rows is an exact multiple of size).
+	err := dbPrewarm.Limit(size + 1).Offset((page - 1) * size).Find(&models).Error
 
 	if err != nil {
 		return rs, err
 	}
 
-	// result := make([]E, 0, len(models))
-	// for _, row := range models {
-	// 	result = append(result, row.ToEntity())
-	// }
+	rs.HasNext = len(models) > size
+	if rs.HasNext {
+		models = models[:size]
+	}
+	rs.Data = r.FromModelToDto(models)
 
-	rs.Data = models
 	return rs, nil
 }
 
-func (r *GormRepository[M, E]) FindAll(ctx context.Context) ([]E, error) {
-	return r.FindWithLimit(ctx, -1, -1)
-}
-
-func (r *GormRepository[M, E]) FindByEntity(ctx context.Context, e any) ([]E, error) {
-	var models []M
-	result := r.db.Where(&e).Find(&models)
-	return r.FromModelToDto(models), result.Error
+func (r *GormRepository[M, E]) FindAll(ctx context.Context, opts ...DBOption) ([]E, error) {
+	return r.FindWithLimit(ctx, -1, -1, opts)
 }
 
-func (r *GormRepository[M, E]) FindByEntityWithOptions(ctx context.Context, e any, eagerLoad bool) ([]E, error) {
+func (r *GormRepository[M, E]) FindByEntity(ctx context.Context, e any, opts ...DBOption) ([]E, error) {
 	var models []M
-	result := r.db.Where(e).Preload(clause.Associations).Find(&models)
+	result := applyOptions(r.readDbFor(ctx), opts).Where(&e).Find(&models)
 	return r.FromModelToDto(models), result.Error
 }
 
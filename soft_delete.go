@@ -0,0 +1,86 @@
+package gorm_generics
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm"
+)
+
+var deletedAtType = reflect.TypeOf(gorm.DeletedAt{})
+
+// SoftDeleteMode documents the three ways a read can treat soft-deleted
+// rows. The default, HideDeleted, is gorm's normal scoped behaviour for
+// models embedding gorm.Model. A mode other than HideDeleted is set as the
+// repository default with GormRepository.SetSoftDeleteMode; for a single
+// call, WithTrashed and WithOnlyTrashed give the IncludeDeleted and
+// OnlyDeleted behaviour.
+type SoftDeleteMode int
+
+const (
+	HideDeleted SoftDeleteMode = iota
+	IncludeDeleted
+	OnlyDeleted
+)
+
+// WithTrashed includes soft-deleted rows alongside live ones.
+func WithTrashed() DBOption {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	}
+}
+
+// WithOnlyTrashed restricts the query to soft-deleted rows only. The type
+// parameter is the model being queried, used to resolve the soft-delete
+// column from gorm's parsed schema (see softDeleteColumn) instead of
+// assuming the column is named "deleted_at".
+func WithOnlyTrashed[M any]() DBOption {
+	return func(db *gorm.DB) *gorm.DB {
+		col, err := softDeleteColumn[M](db)
+		if err != nil {
+			db.AddError(err)
+			return db
+		}
+		return db.Unscoped().Where(col + " IS NOT NULL")
+	}
+}
+
+// softDeleteColumn resolves M's soft-delete column from its schema by
+// finding the field of type gorm.DeletedAt and reading its resolved DB
+// column name. Matching by type rather than by the conventional Go field
+// name "DeletedAt" means this also works for a model that renames the
+// field itself (not just its `gorm:"column:..."` tag).
+func softDeleteColumn[M any](db *gorm.DB) (string, error) {
+	var model M
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(&model); err != nil {
+		return "", err
+	}
+	for _, field := range stmt.Schema.Fields {
+		if field.FieldType == deletedAtType {
+			return field.DBName, nil
+		}
+	}
+	return "", fmt.Errorf("gorm_generics: model has no gorm.DeletedAt field")
+}
+
+// Restore clears deleted_at on the row with the given id, undoing a soft
+// delete.
+func (r *GormRepository[M, E]) Restore(ctx context.Context, id any) error {
+	pkColumn, err := primaryKeyColumn[M](r.db)
+	if err != nil {
+		return err
+	}
+
+	var model M
+	return r.dbFor(ctx).Unscoped().Model(&model).Where(pkColumn+" = ?", id).Update("deleted_at", nil).Error
+}
+
+// AuditHook lets a repository stamp audit columns (e.g. CreatedBy/UpdatedBy)
+// from context values before a model is written, without dropping into raw
+// gorm hooks. Install one with GormRepository.SetAuditHook.
+type AuditHook[M any] interface {
+	BeforeCreate(ctx context.Context, m M) M
+	BeforeUpdate(ctx context.Context, m M) M
+}
@@ -0,0 +1,63 @@
+package gorm_generics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type softDeleteTestModel struct {
+	ID        int64 `gorm:"primaryKey"`
+	Name      string
+	RemovedAt gorm.DeletedAt `gorm:"column:removed_at"`
+}
+
+func (m softDeleteTestModel) ToEntity() softDeleteTestEntity {
+	return softDeleteTestEntity{ID: m.ID, Name: m.Name}
+}
+
+func (m softDeleteTestModel) FromEntity(e softDeleteTestEntity) interface{} {
+	return softDeleteTestModel{ID: e.ID, Name: e.Name}
+}
+
+type softDeleteTestEntity struct {
+	ID   int64
+	Name string
+}
+
+// TestWithOnlyTrashedResolvesCustomColumn is a DB-backed regression test for
+// a model whose soft-delete column isn't named "deleted_at": WithOnlyTrashed
+// must resolve it via schema instead of hardcoding the column name, or the
+// query below would fail (unknown column "deleted_at") or, worse, silently
+// match nothing.
+func TestWithOnlyTrashedResolvesCustomColumn(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&softDeleteTestModel{}); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+
+	live := softDeleteTestModel{ID: 1, Name: "live"}
+	trashed := softDeleteTestModel{ID: 2, Name: "trashed", RemovedAt: gorm.DeletedAt{Time: time.Now(), Valid: true}}
+	if err := db.Create(&live).Error; err != nil {
+		t.Fatalf("create live: %v", err)
+	}
+	if err := db.Unscoped().Create(&trashed).Error; err != nil {
+		t.Fatalf("create trashed: %v", err)
+	}
+
+	repo := NewRepository[softDeleteTestModel, softDeleteTestEntity](db)
+
+	got, err := repo.Find(context.Background(), []DBOption{WithOnlyTrashed[softDeleteTestModel]()})
+	if err != nil {
+		t.Fatalf("Find with WithOnlyTrashed: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != trashed.ID {
+		t.Fatalf("got %+v, want only the trashed row (id=%d)", got, trashed.ID)
+	}
+}
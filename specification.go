@@ -0,0 +1,149 @@
+package gorm_generics
+
+import "strings"
+
+// Specification is a reusable query predicate that GormRepository methods
+// apply when building a query. Implementations supply a raw where clause
+// and its bind values. A bare Specification (one that doesn't also satisfy
+// QuerySpec) is always AND'd into the query, matching the original
+// behaviour of this package.
+type Specification interface {
+	GetQuery() string
+	GetValues() []interface{}
+}
+
+// SpecKind tells getPreWarmDbForSelect how to fold a QuerySpec into the
+// query being built, rather than always AND-ing it as a where clause.
+type SpecKind int
+
+const (
+	KindWhere SpecKind = iota
+	// KindOr marks a spec built with Or. Its query string is already a
+	// self-contained parenthesised OR group, so getPreWarmDbForSelect folds
+	// it in the same way as KindWhere (AND'd against the rest) rather than
+	// calling gorm's Or, which would OR against the entire chain built so
+	// far instead of just the group.
+	KindOr
+	KindOrder
+	KindGroup
+	KindHaving
+	KindJoin
+	KindPreload
+)
+
+// QuerySpec is a Specification that declares which part of the query it
+// contributes to. Implement it directly for custom domain predicates that
+// need to do more than AND a where clause; the constructors below (And, Or,
+// Not, OrderBy, GroupBy, Having, Join, PreloadSpec, ...) cover the common
+// cases.
+type QuerySpec interface {
+	Specification
+	Kind() SpecKind
+}
+
+// preloadSpec is implemented by specs built with PreloadSpec so
+// getPreWarmDbForSelect can scope the preloaded association with the
+// nested specs, without a package-wide dependency on the concrete type.
+type preloadSpec interface {
+	Specification
+	Nested() []Specification
+}
+
+type baseSpec struct {
+	kind   SpecKind
+	query  string
+	values []interface{}
+	nested []Specification
+}
+
+func (s baseSpec) GetQuery() string         { return s.query }
+func (s baseSpec) GetValues() []interface{} { return s.values }
+func (s baseSpec) Kind() SpecKind           { return s.kind }
+func (s baseSpec) Nested() []Specification  { return s.nested }
+
+// Eq matches rows where col equals v.
+func Eq(col string, v interface{}) Specification {
+	return baseSpec{kind: KindWhere, query: col + " = ?", values: []interface{}{v}}
+}
+
+// In matches rows where col is one of vs.
+func In(col string, vs ...interface{}) Specification {
+	return baseSpec{kind: KindWhere, query: col + " IN ?", values: []interface{}{vs}}
+}
+
+// Like matches rows where col matches the SQL LIKE pattern.
+func Like(col, pattern string) Specification {
+	return baseSpec{kind: KindWhere, query: col + " LIKE ?", values: []interface{}{pattern}}
+}
+
+// Between matches rows where col is between lo and hi, inclusive.
+func Between(col string, lo, hi interface{}) Specification {
+	return baseSpec{kind: KindWhere, query: col + " BETWEEN ? AND ?", values: []interface{}{lo, hi}}
+}
+
+// IsNull matches rows where col is NULL.
+func IsNull(col string) Specification {
+	return baseSpec{kind: KindWhere, query: col + " IS NULL"}
+}
+
+// And groups specs so they're applied as a single parenthesised AND clause,
+// e.g. for combining with an Or group.
+func And(specs ...Specification) Specification {
+	query, values := combineSpecs(specs, " AND ")
+	return baseSpec{kind: KindWhere, query: "(" + query + ")", values: values}
+}
+
+// Or groups specs into a single parenthesised OR clause, e.g.
+// Or(Eq("a", 1), Eq("b", 2)) produces "(a = ? OR b = ?)". Like any other
+// Specification, the group itself is AND'd against whatever else is in the
+// spec list - it does not OR against unrelated specs that happen to
+// precede or follow it in the call.
+func Or(specs ...Specification) Specification {
+	query, values := combineSpecs(specs, " OR ")
+	return baseSpec{kind: KindOr, query: "(" + query + ")", values: values}
+}
+
+// Not negates spec.
+func Not(spec Specification) Specification {
+	return baseSpec{kind: KindWhere, query: "NOT (" + spec.GetQuery() + ")", values: spec.GetValues()}
+}
+
+func combineSpecs(specs []Specification, sep string) (string, []interface{}) {
+	parts := make([]string, len(specs))
+	var values []interface{}
+	for i, s := range specs {
+		parts[i] = s.GetQuery()
+		values = append(values, s.GetValues()...)
+	}
+	return strings.Join(parts, sep), values
+}
+
+// OrderBy appends an ORDER BY on col, ascending unless desc is true.
+func OrderBy(col string, desc bool) Specification {
+	dir := "ASC"
+	if desc {
+		dir = "DESC"
+	}
+	return baseSpec{kind: KindOrder, query: col + " " + dir}
+}
+
+// GroupBy appends a GROUP BY on cols.
+func GroupBy(cols ...string) Specification {
+	return baseSpec{kind: KindGroup, query: strings.Join(cols, ", ")}
+}
+
+// Having appends a HAVING clause.
+func Having(expr string, args ...interface{}) Specification {
+	return baseSpec{kind: KindHaving, query: expr, values: args}
+}
+
+// Join appends a join, e.g. Join("LEFT", "orders", "orders.user_id = users.id").
+func Join(kind, table, on string, args ...interface{}) Specification {
+	return baseSpec{kind: KindJoin, query: strings.ToUpper(kind) + " JOIN " + table + " ON " + on, values: args}
+}
+
+// PreloadSpec eager-loads assoc, optionally scoping the preloaded rows with
+// nested specs (applied as where clauses against the association's table).
+func PreloadSpec(assoc string, nested ...Specification) Specification {
+	return baseSpec{kind: KindPreload, query: assoc, nested: nested}
+}
@@ -0,0 +1,121 @@
+package gorm_generics
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEqInLikeBetweenIsNull(t *testing.T) {
+	cases := []struct {
+		name       string
+		spec       Specification
+		wantQuery  string
+		wantValues []interface{}
+	}{
+		{"Eq", Eq("status", "active"), "status = ?", []interface{}{"active"}},
+		{"In", In("id", 1, 2, 3), "id IN ?", []interface{}{[]interface{}{1, 2, 3}}},
+		{"Like", Like("name", "%foo%"), "name LIKE ?", []interface{}{"%foo%"}},
+		{"Between", Between("age", 18, 30), "age BETWEEN ? AND ?", []interface{}{18, 30}},
+		{"IsNull", IsNull("deleted_at"), "deleted_at IS NULL", nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.spec.GetQuery(); got != c.wantQuery {
+				t.Fatalf("GetQuery() = %q, want %q", got, c.wantQuery)
+			}
+			if got := c.spec.GetValues(); !reflect.DeepEqual(got, c.wantValues) {
+				t.Fatalf("GetValues() = %#v, want %#v", got, c.wantValues)
+			}
+		})
+	}
+}
+
+func TestOrSelfParenthesisesAndReportsOrKind(t *testing.T) {
+	spec := Or(Eq("a", 1), Eq("b", 2))
+
+	const want = "(a = ? OR b = ?)"
+	if got := spec.GetQuery(); got != want {
+		t.Fatalf("GetQuery() = %q, want %q", got, want)
+	}
+	if !reflect.DeepEqual(spec.GetValues(), []interface{}{1, 2}) {
+		t.Fatalf("GetValues() = %#v", spec.GetValues())
+	}
+
+	// Or still reports KindOr for introspection, but applySpecs (used by
+	// getPreWarmDbForSelect) must fold it in the same way as KindWhere
+	// (AND'd against the rest) since the query string is already a
+	// self-contained OR group - see repository.go's applySpecs default case.
+	qs, ok := spec.(QuerySpec)
+	if !ok {
+		t.Fatalf("Or() result does not implement QuerySpec")
+	}
+	if qs.Kind() != KindOr {
+		t.Fatalf("Kind() = %v, want KindOr", qs.Kind())
+	}
+}
+
+func TestAndGroupsSpecs(t *testing.T) {
+	spec := And(Eq("a", 1), Eq("b", 2))
+
+	const want = "(a = ? AND b = ?)"
+	if got := spec.GetQuery(); got != want {
+		t.Fatalf("GetQuery() = %q, want %q", got, want)
+	}
+	if !reflect.DeepEqual(spec.GetValues(), []interface{}{1, 2}) {
+		t.Fatalf("GetValues() = %#v", spec.GetValues())
+	}
+}
+
+func TestNotNegatesSpec(t *testing.T) {
+	spec := Not(Eq("status", "banned"))
+
+	const want = "NOT (status = ?)"
+	if got := spec.GetQuery(); got != want {
+		t.Fatalf("GetQuery() = %q, want %q", got, want)
+	}
+	if !reflect.DeepEqual(spec.GetValues(), []interface{}{"banned"}) {
+		t.Fatalf("GetValues() = %#v", spec.GetValues())
+	}
+}
+
+func TestOrderByGroupByHavingJoin(t *testing.T) {
+	if got := OrderBy("created_at", true).GetQuery(); got != "created_at DESC" {
+		t.Fatalf("OrderBy desc = %q", got)
+	}
+	if got := OrderBy("created_at", false).GetQuery(); got != "created_at ASC" {
+		t.Fatalf("OrderBy asc = %q", got)
+	}
+	if got := GroupBy("account_id", "status").GetQuery(); got != "account_id, status" {
+		t.Fatalf("GroupBy = %q", got)
+	}
+
+	having := Having("COUNT(*) > ?", 1)
+	if got := having.GetQuery(); got != "COUNT(*) > ?" {
+		t.Fatalf("Having query = %q", got)
+	}
+	if !reflect.DeepEqual(having.GetValues(), []interface{}{1}) {
+		t.Fatalf("Having values = %#v", having.GetValues())
+	}
+
+	join := Join("left", "orders", "orders.user_id = users.id")
+	if got := join.GetQuery(); got != "LEFT JOIN orders ON orders.user_id = users.id" {
+		t.Fatalf("Join query = %q", got)
+	}
+}
+
+func TestPreloadSpecExposesNested(t *testing.T) {
+	nested := Eq("status", "active")
+	spec := PreloadSpec("Orders", nested)
+
+	ps, ok := spec.(preloadSpec)
+	if !ok {
+		t.Fatalf("PreloadSpec() result does not implement preloadSpec")
+	}
+	if spec.GetQuery() != "Orders" {
+		t.Fatalf("GetQuery() = %q, want %q", spec.GetQuery(), "Orders")
+	}
+	if got := ps.Nested(); len(got) != 1 || !reflect.DeepEqual(got[0], nested) {
+		t.Fatalf("Nested() = %#v, want [nested]", got)
+	}
+}